@@ -0,0 +1,172 @@
+package kivik
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"sort"
+
+	"github.com/IG-Soft/kivik/v3/driver"
+)
+
+// Attachment represents a file attachment on a document.
+type Attachment struct {
+	// Content is the attachment content. It is the caller's responsibility
+	// to close this.
+	Content io.ReadCloser `json:"-"`
+	// Filename is the name of the attachment. For an attachment fetched
+	// from a document's _attachments map, this is the map key.
+	Filename string `json:"-"`
+	// ContentType is the MIME type of the attachment.
+	ContentType string `json:"content_type"`
+	// Stub is true if Content is a stub, and the real content must be
+	// fetched separately.
+	Stub bool `json:"-"`
+	// Follows is true if the attachment content is to be (or was) sent as
+	// a subsequent part of a multipart/related request or response,
+	// rather than inlined as base64 data.
+	Follows bool `json:"-"`
+	// Size is the content length of the attachment, in bytes.
+	Size int64 `json:"-"`
+	// RevPos is the revision number when this attachment was added.
+	RevPos int64 `json:"-"`
+}
+
+// jsonAttachment is used to marshal and unmarshal the JSON representation
+// of an Attachment, as it appears inline in a document's _attachments map.
+type jsonAttachment struct {
+	ContentType string `json:"content_type"`
+	RevPos      int64  `json:"revpos,omitempty"`
+	Follows     bool   `json:"follows,omitempty"`
+	Stub        bool   `json:"stub,omitempty"`
+	Size        int64  `json:"length,omitempty"`
+	Data        []byte `json:"data,omitempty"`
+}
+
+// MarshalJSON satisfies the json.Marshaler interface. An attachment that
+// Follows is encoded as a stub, with its content to be sent as a separate
+// multipart part; a Stub is encoded with its length only; anything else is
+// read in full and base64-inlined as "data".
+func (a *Attachment) MarshalJSON() ([]byte, error) {
+	att := jsonAttachment{
+		ContentType: a.ContentType,
+		RevPos:      a.RevPos,
+		Follows:     a.Follows,
+		Stub:        a.Stub,
+	}
+	switch {
+	case a.Follows:
+		// Content is sent separately, as a subsequent multipart part.
+	case a.Stub:
+		att.Size = a.Size
+	default:
+		content, err := ioutil.ReadAll(a.Content)
+		if err != nil {
+			return nil, err
+		}
+		att.Data = content
+	}
+	return json.Marshal(att)
+}
+
+// UnmarshalJSON satisfies the json.Unmarshaler interface.
+func (a *Attachment) UnmarshalJSON(data []byte) error {
+	var att jsonAttachment
+	if err := json.Unmarshal(data, &att); err != nil {
+		return err
+	}
+	a.ContentType = att.ContentType
+	a.RevPos = att.RevPos
+	a.Follows = att.Follows
+	a.Stub = att.Stub
+	a.Size = att.Size
+	a.Content = ioutil.NopCloser(bytes.NewReader(att.Data))
+	return nil
+}
+
+// Attachments is a collection of attachments, as found in a document's
+// _attachments field, keyed by filename.
+type Attachments map[string]*Attachment
+
+// UnmarshalJSON satisfies the json.Unmarshaler interface, populating each
+// attachment's Filename from its map key.
+func (a *Attachments) UnmarshalJSON(data []byte) error {
+	raw := make(map[string]*Attachment)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for filename, att := range raw {
+		att.Filename = filename
+	}
+	*a = raw
+	return nil
+}
+
+// newAttachmentsIter wraps atts in a driver.Attachments, so it can be
+// streamed to a MultipartAttacher's PutMultipart method in filename order.
+func newAttachmentsIter(atts Attachments) driver.Attachments {
+	names := make([]string, 0, len(atts))
+	for name := range atts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return &attachmentsIter{atts: atts, names: names}
+}
+
+type attachmentsIter struct {
+	atts  Attachments
+	names []string
+}
+
+func (i *attachmentsIter) Next(att *driver.Attachment) error {
+	if len(i.names) == 0 {
+		return io.EOF
+	}
+	name := i.names[0]
+	i.names = i.names[1:]
+	a := i.atts[name]
+	*att = driver.Attachment{
+		Filename:    name,
+		ContentType: a.ContentType,
+		Stub:        a.Stub,
+		Follows:     a.Follows,
+		Content:     a.Content,
+		Size:        a.Size,
+		RevPos:      a.RevPos,
+	}
+	return nil
+}
+
+func (i *attachmentsIter) Close() error {
+	return nil
+}
+
+// AttachmentsIterator allows reading a stream of attachments, such as from
+// a multipart/related GET response, without buffering them all in memory.
+type AttachmentsIterator struct {
+	atti driver.Attachments
+}
+
+// Next returns the next attachment in the iterator, or an error. When the
+// iterator is exhausted, Next returns io.EOF.
+func (i *AttachmentsIterator) Next() (*Attachment, error) {
+	var att driver.Attachment
+	if err := i.atti.Next(&att); err != nil {
+		return nil, wrapError(err)
+	}
+	return &Attachment{
+		Content:     att.Content,
+		Filename:    att.Filename,
+		ContentType: att.ContentType,
+		Stub:        att.Stub,
+		Follows:     att.Follows,
+		Size:        att.Size,
+		RevPos:      att.RevPos,
+	}, nil
+}
+
+// Close closes the iterator, releasing any associated resources.
+func (i *AttachmentsIterator) Close() error {
+	return wrapError(i.atti.Close())
+}
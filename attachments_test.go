@@ -11,8 +11,8 @@ import (
 	"github.com/flimzy/diff"
 	"github.com/flimzy/testy"
 
-	"github.com/go-kivik/kivik/v4/driver"
-	"github.com/go-kivik/kivik/v4/internal/mock"
+	"github.com/IG-Soft/kivik/v3/driver"
+	"github.com/IG-Soft/kivik/v3/internal/mock"
 )
 
 func TestAttachmentMarshalJSON(t *testing.T) {
@@ -0,0 +1,159 @@
+package kivik
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/IG-Soft/kivik/v3/driver"
+)
+
+// BulkGetReference identifies a single document revision to request via
+// DB.BulkGet.
+type BulkGetReference struct {
+	// ID is the document ID.
+	ID string
+	// Rev is the specific revision to fetch. If empty, the winning
+	// revision is returned.
+	Rev string
+}
+
+// BulkResult is a single row of a bulk operation's result, as returned by a
+// BulkDocsIter.
+type BulkResult struct {
+	// ID is the document ID this row pertains to.
+	ID string
+	// Rev is the resulting (or fetched) revision, when Ok is true.
+	Rev string
+	// Doc is the raw document body, populated for successful BulkGet
+	// rows.
+	Doc []byte
+	// Ok is true if this row completed successfully.
+	Ok bool
+	// Error, when non-nil, explains why this row failed.
+	Error error
+}
+
+// BulkDocsIter is an iterator over the rows of a bulk operation, returned
+// by DB.BulkGet and DB.BulkDocs. The caller must call Close once done with
+// the iterator, whether or not iteration ran to completion; this releases
+// the underlying connection promptly even if ctx is canceled mid-stream.
+type BulkDocsIter struct {
+	results driver.BulkResults
+}
+
+// Next populates the next row into a freshly-allocated BulkResult, and
+// returns it. It returns io.EOF once the iterator is exhausted.
+func (i *BulkDocsIter) Next() (*BulkResult, error) {
+	var res driver.BulkResult
+	if err := i.results.Next(&res); err != nil {
+		return nil, wrapError(err)
+	}
+	return &BulkResult{
+		ID:    res.ID,
+		Rev:   res.Rev,
+		Doc:   res.Doc,
+		Ok:    res.Ok,
+		Error: wrapError(res.Error),
+	}, nil
+}
+
+// Close closes the iterator, releasing any associated resources.
+func (i *BulkDocsIter) Close() error {
+	return wrapError(i.results.Close())
+}
+
+// Errors drains the remainder of the iterator and closes it, aggregating
+// every failed row's error into a *MultiError. It returns nil if every
+// remaining row succeeded, so callers who don't need individual rows can
+// write:
+//
+//	iter, err := db.BulkDocs(ctx, docs, nil)
+//	...
+//	if err := iter.Errors(); err != nil {
+//		if errors.Is(err, kivik.ErrConflict) { ... }
+//	}
+func (i *BulkDocsIter) Errors() error {
+	defer i.Close() // nolint: errcheck
+	var errs []error
+	for {
+		row, err := i.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if !row.Ok && row.Error != nil {
+			errs = append(errs, row.Error)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return &MultiError{Errors: errs}
+}
+
+// BulkGet fetches multiple document revisions in a single round trip, via
+// CouchDB's _bulk_get endpoint, if the driver supports it.
+func (db *DB) BulkGet(ctx context.Context, docs []BulkGetReference, options map[string]interface{}) (*BulkDocsIter, error) {
+	bg, ok := db.client.(driver.BulkGetter)
+	if !ok {
+		return nil, &Error{HTTPStatus: http.StatusNotImplemented, Err: errors.New("kivik: driver does not support BulkGet")}
+	}
+	refs := make([]driver.BulkGetReference, len(docs))
+	for i, d := range docs {
+		refs[i] = driver.BulkGetReference{ID: d.ID, Rev: d.Rev}
+	}
+	results, err := bg.BulkGet(ctx, refs, options)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return &BulkDocsIter{results: results}, nil
+}
+
+// BulkDocs creates or updates the given documents in a single request. When
+// the driver implements BulkDocsStreamer, the server's response is decoded
+// one row at a time, so inserting a large number of documents doesn't
+// require buffering the full result set in memory; otherwise the driver's
+// buffered BulkDocer is used, and its results are served through the same
+// iterator so callers don't need to care which path was taken.
+func (db *DB) BulkDocs(ctx context.Context, docs []interface{}, options map[string]interface{}) (*BulkDocsIter, error) {
+	if bs, ok := db.client.(driver.BulkDocsStreamer); ok {
+		results, err := bs.BulkDocsStream(ctx, docs, options)
+		if err != nil {
+			return nil, wrapError(err)
+		}
+		return &BulkDocsIter{results: results}, nil
+	}
+	bd, ok := db.client.(driver.BulkDocer)
+	if !ok {
+		return nil, &Error{HTTPStatus: http.StatusNotImplemented, Err: errors.New("kivik: driver does not support BulkDocs")}
+	}
+	rows, err := bd.BulkDocs(ctx, docs, options)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return &BulkDocsIter{results: &sliceBulkResults{rows: rows}}, nil
+}
+
+// sliceBulkResults adapts a pre-buffered []driver.BulkResult, as returned
+// by a BulkDocer, to the driver.BulkResults streaming interface, so
+// buffered and streamed drivers can share a single kivik.BulkDocsIter.
+type sliceBulkResults struct {
+	rows []driver.BulkResult
+}
+
+func (s *sliceBulkResults) Next(result *driver.BulkResult) error {
+	if len(s.rows) == 0 {
+		return io.EOF
+	}
+	*result = s.rows[0]
+	s.rows = s.rows[1:]
+	return nil
+}
+
+func (s *sliceBulkResults) Close() error {
+	return nil
+}
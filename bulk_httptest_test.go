@@ -0,0 +1,132 @@
+package kivik
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestDBBulkDocsStreamWire drives DB.BulkDocs against a real streaming
+// _bulk_docs response, confirming each row comes back in order, followed
+// by io.EOF, and that a row carrying CouchDB's {"error":...} shape comes
+// back as a typed, errors.Is-matchable error.
+func TestDBBulkDocsStreamWire(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Docs []interface{} `json:"docs"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		if len(req.Docs) != 2 {
+			t.Fatalf("unexpected docs: %v", req.Docs)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id":"foo","rev":"1-xxx"},{"id":"bar","error":"conflict","reason":"Document update conflict."}]`))
+	}))
+	defer server.Close()
+
+	db := newRefDB(t, server)
+	iter, err := db.BulkDocs(context.Background(), []interface{}{
+		map[string]string{"_id": "foo"},
+		map[string]string{"_id": "bar"},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer iter.Close() // nolint: errcheck
+
+	row, err := iter.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !row.Ok || row.ID != "foo" {
+		t.Errorf("unexpected first row: %+v", row)
+	}
+
+	row, err = iter.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if row.Ok || row.ID != "bar" || row.Error == nil {
+		t.Errorf("unexpected second row: %+v", row)
+	}
+	if !errors.Is(row.Error, ErrConflict) {
+		t.Errorf("expected a conflict error, got %v", row.Error)
+	}
+
+	if _, err := iter.Next(); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
+
+// TestDBBulkDocsStreamMalformedJSON feeds a truncated row into the
+// decoder, verifying Next reports a decode error instead of panicking or
+// silently dropping the rest of the stream.
+func TestDBBulkDocsStreamMalformedJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(ioutil.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id":"foo","rev":"1-xxx"},{"id":"bar","rev" `)) // truncated mid-stream
+	}))
+	defer server.Close()
+
+	db := newRefDB(t, server)
+	iter, err := db.BulkDocs(context.Background(), []interface{}{map[string]string{"_id": "foo"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer iter.Close() // nolint: errcheck
+
+	if _, err := iter.Next(); err != nil {
+		t.Fatalf("expected the first, well-formed row to decode cleanly, got %v", err)
+	}
+	if _, err := iter.Next(); err == nil || err == io.EOF {
+		t.Fatalf("expected a decode error for the truncated row, got %v", err)
+	}
+}
+
+// TestDBBulkDocsStreamCtxCancelClosesBody verifies that canceling ctx
+// mid-stream closes the underlying HTTP connection promptly, rather than
+// leaving the server hanging on a client that's given up.
+func TestDBBulkDocsStreamCtxCancelClosesBody(t *testing.T) {
+	serverDone := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(ioutil.Discard, r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		flusher, _ := w.(http.Flusher)
+		_, _ = w.Write([]byte(`[{"id":"foo","rev":"1-xxx"},`))
+		if flusher != nil {
+			flusher.Flush()
+		}
+		<-r.Context().Done()
+		close(serverDone)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	db := newRefDB(t, server)
+	iter, err := db.BulkDocs(ctx, []interface{}{map[string]string{"_id": "foo"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer iter.Close() // nolint: errcheck
+	if _, err := iter.Next(); err != nil {
+		t.Fatal(err)
+	}
+
+	cancel()
+
+	select {
+	case <-serverDone:
+	case <-time.After(2 * time.Second):
+		t.Fatal("server did not observe the client closing the connection after ctx was canceled")
+	}
+}
+
@@ -0,0 +1,174 @@
+package kivik
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/flimzy/diff"
+
+	"github.com/IG-Soft/kivik/v3/driver"
+	"github.com/IG-Soft/kivik/v3/internal/mock"
+)
+
+func TestDBBulkGet(t *testing.T) {
+	rows := []driver.BulkResult{
+		{ID: "foo", Rev: "1-xxx", Doc: []byte(`{"_id":"foo"}`), Ok: true},
+		{ID: "bar", Error: &Error{HTTPStatus: 409, Err: errors.New("conflict")}},
+	}
+	db := &DB{client: &mock.BulkGetDB{
+		BulkGetFunc: func(_ context.Context, docs []driver.BulkGetReference, _ map[string]interface{}) (driver.BulkResults, error) {
+			if len(docs) != 1 || docs[0].ID != "foo" {
+				t.Fatalf("unexpected docs: %v", docs)
+			}
+			return &sliceBulkResults{rows: rows}, nil
+		},
+	}}
+	iter, err := db.BulkGet(context.Background(), []BulkGetReference{{ID: "foo"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer iter.Close() // nolint: errcheck
+	var got []*BulkResult
+	for {
+		row, err := iter.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, row)
+	}
+	if len(got) != 2 || !got[0].Ok || got[1].Error == nil {
+		t.Errorf("unexpected rows: %+v", got)
+	}
+}
+
+func TestBulkDocsIterErrors(t *testing.T) {
+	rows := []driver.BulkResult{
+		{ID: "foo", Rev: "1-xxx", Ok: true},
+		{ID: "bar", Error: &Error{HTTPStatus: 409, Err: errors.New("conflict")}},
+		{ID: "baz", Error: &Error{HTTPStatus: 403, Err: errors.New("forbidden")}},
+	}
+	iter := &BulkDocsIter{results: &sliceBulkResults{rows: rows}}
+	err := iter.Errors()
+	if err == nil {
+		t.Fatal("expected a non-nil error")
+	}
+	if !errors.Is(err, ErrConflict) {
+		t.Error("expected the aggregated error to match ErrConflict")
+	}
+	if !errors.Is(err, ErrForbidden) {
+		t.Error("expected the aggregated error to match ErrForbidden")
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Error("expected the aggregated error not to match ErrNotFound")
+	}
+}
+
+func TestBulkDocsIterErrorsAllOk(t *testing.T) {
+	rows := []driver.BulkResult{
+		{ID: "foo", Rev: "1-xxx", Ok: true},
+		{ID: "bar", Rev: "1-xxx", Ok: true},
+	}
+	iter := &BulkDocsIter{results: &sliceBulkResults{rows: rows}}
+	if err := iter.Errors(); err != nil {
+		t.Errorf("expected a nil error, got %v", err)
+	}
+}
+
+func TestDBBulkGetUnsupported(t *testing.T) {
+	db := &DB{client: &mock.DB{}}
+	if _, err := db.BulkGet(context.Background(), nil, nil); err == nil {
+		t.Error("expected an error for an unsupported driver")
+	}
+}
+
+func TestDBBulkDocsStreams(t *testing.T) {
+	var closed bool
+	db := &DB{client: &mock.BulkDocsStreamDB{
+		BulkDocsStreamFunc: func(_ context.Context, docs []interface{}, _ map[string]interface{}) (driver.BulkResults, error) {
+			if len(docs) != 2 {
+				t.Fatalf("unexpected docs: %v", docs)
+			}
+			i := 0
+			rows := []driver.BulkResult{{ID: "1", Rev: "1-x", Ok: true}, {ID: "2", Rev: "1-x", Ok: true}}
+			return &mock.BulkResults{
+				NextFunc: func(res *driver.BulkResult) error {
+					if i >= len(rows) {
+						return io.EOF
+					}
+					*res = rows[i]
+					i++
+					return nil
+				},
+				CloseFunc: func() error {
+					closed = true
+					return nil
+				},
+			}, nil
+		},
+	}}
+	iter, err := db.BulkDocs(context.Background(), []interface{}{map[string]string{"a": "1"}, map[string]string{"a": "2"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for {
+		row, err := iter.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, row.ID)
+	}
+	if err := iter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !closed {
+		t.Error("expected the underlying stream to be closed")
+	}
+	if d := diff.Interface([]string{"1", "2"}, ids); d != nil {
+		t.Error(d)
+	}
+}
+
+func TestDBBulkDocsFallsBackToBuffered(t *testing.T) {
+	db := &DB{client: &mock.BulkDocerDB{
+		BulkDocsFunc: func(_ context.Context, docs []interface{}, _ map[string]interface{}) ([]driver.BulkResult, error) {
+			return []driver.BulkResult{
+				{ID: "1", Rev: "1-x", Ok: true},
+				{ID: "2", Rev: "1-x", Ok: true},
+			}, nil
+		},
+	}}
+	iter, err := db.BulkDocs(context.Background(), []interface{}{map[string]string{"a": "1"}, map[string]string{"a": "2"}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for {
+		row, err := iter.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, row.ID)
+	}
+	if d := diff.Interface([]string{"1", "2"}, ids); d != nil {
+		t.Error(d)
+	}
+}
+
+func TestDBBulkDocsUnsupported(t *testing.T) {
+	db := &DB{client: &mock.DB{}}
+	if _, err := db.BulkDocs(context.Background(), nil, nil); err == nil {
+		t.Error("expected an error for an unsupported driver")
+	}
+}
@@ -0,0 +1,60 @@
+package kivik
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/IG-Soft/kivik/v3/driver"
+)
+
+var driversMu sync.RWMutex
+var drivers = make(map[string]driver.Driver)
+
+// Register makes a database driver available by the provided name. If
+// Register is called twice with the same name, or if driver is nil, it
+// panics.
+func Register(name string, driver driver.Driver) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	if driver == nil {
+		panic("kivik: Register driver is nil")
+	}
+	if _, dup := drivers[name]; dup {
+		panic("kivik: Register called twice for driver " + name)
+	}
+	drivers[name] = driver
+}
+
+// Client is a client to a CouchDB-like server.
+type Client struct {
+	driverName   string
+	dsn          string
+	driverClient driver.Client
+}
+
+// New creates a new client object, for the driver and data source name
+// specified.
+func New(driverName, dataSourceName string) (*Client, error) {
+	driversMu.RLock()
+	driveri, ok := drivers[driverName]
+	driversMu.RUnlock()
+	if !ok {
+		return nil, &Error{HTTPStatus: http.StatusBadRequest, Err: fmt.Errorf("kivik: unknown driver %q (forgotten import?)", driverName)}
+	}
+	driverClient, err := driveri.NewClient(dataSourceName)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return &Client{driverName: driverName, dsn: dataSourceName, driverClient: driverClient}, nil
+}
+
+// DB returns a handle to the requested database.
+func (c *Client) DB(ctx context.Context, dbName string, options map[string]interface{}) (*DB, error) {
+	db, err := c.driverClient.DB(ctx, dbName, options)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return &DB{client: db}, nil
+}
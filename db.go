@@ -0,0 +1,86 @@
+package kivik
+
+import (
+	"context"
+	"io"
+
+	"github.com/IG-Soft/kivik/v3/driver"
+)
+
+// DB is a handle to a specific database.
+type DB struct {
+	client driver.DB
+}
+
+// Document is a document as returned by DB.Get. If the document was
+// fetched with attachments, and the driver supports streaming them via
+// multipart/related, Attachments is non-nil and must be drained (or
+// closed) by the caller to release the underlying connection.
+type Document struct {
+	// ContentLength is the length of Body, if known.
+	ContentLength int64
+	// Rev is the revision of the returned document.
+	Rev string
+	// Body is the raw JSON of the document itself.
+	Body io.ReadCloser
+	// Attachments streams any attachments returned alongside the
+	// document, in the order they appeared on the wire.
+	Attachments *AttachmentsIterator
+}
+
+// Get fetches the requested document. If options["attachments"] is true
+// and the driver supports driver.MultipartAttacher, the document and its
+// attachments are streamed in a single multipart/related response, and the
+// returned Document's Attachments iterator yields them without buffering.
+// Otherwise, attachments (if requested) come back base64-inlined in Body,
+// as usual.
+func (db *DB) Get(ctx context.Context, docID string, options map[string]interface{}) (*Document, error) {
+	if wantAttachments(options) {
+		if ma, ok := db.client.(driver.MultipartAttacher); ok {
+			doc, atts, err := ma.GetMultipart(ctx, docID, options)
+			if err != nil {
+				return nil, wrapError(err)
+			}
+			return &Document{
+				ContentLength: doc.ContentLength,
+				Rev:           doc.Rev,
+				Body:          doc.Body,
+				Attachments:   &AttachmentsIterator{atti: atts},
+			}, nil
+		}
+	}
+	doc, err := db.client.Get(ctx, docID, options)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return &Document{
+		ContentLength: doc.ContentLength,
+		Rev:           doc.Rev,
+		Body:          doc.Body,
+	}, nil
+}
+
+// Put writes doc under docID, creating or updating it. If atts is
+// non-empty and the driver supports driver.MultipartAttacher, the document
+// and its attachments are streamed as a single multipart/related request,
+// with "follows": true stubs in doc's JSON standing in for the attachment
+// bodies sent in subsequent parts. Put does not add those stubs: doc is
+// sent as given, so its _attachments field must already carry a
+// "follows": true stub for each entry in atts. Otherwise, the caller is
+// responsible for ensuring doc's own _attachments field inlines attachment content as
+// base64 data, and atts is ignored.
+func (db *DB) Put(ctx context.Context, docID string, doc interface{}, atts Attachments, options map[string]interface{}) (rev string, err error) {
+	if len(atts) > 0 {
+		if ma, ok := db.client.(driver.MultipartAttacher); ok {
+			rev, err = ma.PutMultipart(ctx, docID, doc, newAttachmentsIter(atts), options)
+			return rev, wrapError(err)
+		}
+	}
+	rev, err = db.client.Put(ctx, docID, doc, options)
+	return rev, wrapError(err)
+}
+
+func wantAttachments(options map[string]interface{}) bool {
+	atts, _ := options["attachments"].(bool)
+	return atts
+}
@@ -0,0 +1,150 @@
+package kivik
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/flimzy/diff"
+
+	"github.com/IG-Soft/kivik/v3/driver"
+	"github.com/IG-Soft/kivik/v3/internal/mock"
+)
+
+func TestDBPutMultipart(t *testing.T) {
+	atts := Attachments{
+		"foo.txt": {
+			Content:     ioutil.NopCloser(bytes.NewReader([]byte("test content"))),
+			ContentType: "text/plain",
+		},
+	}
+	var gotDocID string
+	var gotFollows []string
+	db := &DB{client: &mock.MultipartDB{
+		PutMultipartFunc: func(_ context.Context, docID string, _ interface{}, ai driver.Attachments, _ map[string]interface{}) (string, error) {
+			gotDocID = docID
+			for {
+				var att driver.Attachment
+				err := ai.Next(&att)
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				gotFollows = append(gotFollows, att.Filename)
+			}
+			return "1-xxx", nil
+		},
+	}}
+	rev, err := db.Put(context.Background(), "doc1", map[string]string{"foo": "bar"}, atts, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rev != "1-xxx" {
+		t.Errorf("unexpected rev: %s", rev)
+	}
+	if gotDocID != "doc1" {
+		t.Errorf("unexpected docID: %s", gotDocID)
+	}
+	if d := diff.Interface([]string{"foo.txt"}, gotFollows); d != nil {
+		t.Error(d)
+	}
+}
+
+func TestDBPutMultipartStableOrder(t *testing.T) {
+	atts := Attachments{
+		"c.txt": {Content: ioutil.NopCloser(bytes.NewReader([]byte("c"))), ContentType: "text/plain"},
+		"a.txt": {Content: ioutil.NopCloser(bytes.NewReader([]byte("a"))), ContentType: "text/plain"},
+		"b.txt": {Content: ioutil.NopCloser(bytes.NewReader([]byte("b"))), ContentType: "text/plain"},
+		"e.txt": {Content: ioutil.NopCloser(bytes.NewReader([]byte("e"))), ContentType: "text/plain"},
+		"d.txt": {Content: ioutil.NopCloser(bytes.NewReader([]byte("d"))), ContentType: "text/plain"},
+	}
+	want := []string{"a.txt", "b.txt", "c.txt", "d.txt", "e.txt"}
+	db := &DB{client: &mock.MultipartDB{
+		PutMultipartFunc: func(_ context.Context, _ string, _ interface{}, ai driver.Attachments, _ map[string]interface{}) (string, error) {
+			var got []string
+			for {
+				var att driver.Attachment
+				err := ai.Next(&att)
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, att.Filename)
+			}
+			if d := diff.Interface(want, got); d != nil {
+				t.Error(d)
+			}
+			return "1-xxx", nil
+		},
+	}}
+	for i := 0; i < 20; i++ {
+		if _, err := db.Put(context.Background(), "doc1", map[string]string{"foo": "bar"}, atts, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDBPutNoAttachmentsFallsBackToPut(t *testing.T) {
+	var called bool
+	db := &DB{client: &mock.DB{
+		PutFunc: func(_ context.Context, docID string, _ interface{}, _ map[string]interface{}) (string, error) {
+			called = true
+			return "1-xxx", nil
+		},
+	}}
+	if _, err := db.Put(context.Background(), "doc1", map[string]string{"foo": "bar"}, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if !called {
+		t.Error("expected plain Put to be called")
+	}
+}
+
+func TestDBGetMultipart(t *testing.T) {
+	db := &DB{client: &mock.MultipartDB{
+		GetMultipartFunc: func(_ context.Context, docID string, _ map[string]interface{}) (*driver.Document, driver.Attachments, error) {
+			return &driver.Document{Rev: "1-xxx", Body: ioutil.NopCloser(bytes.NewReader([]byte(`{}`)))},
+				&mock.Attachments{
+					NextFunc: func(att *driver.Attachment) error {
+						*att = driver.Attachment{Filename: "foo.txt"}
+						return nil
+					},
+				}, nil
+		},
+	}}
+	doc, err := db.Get(context.Background(), "doc1", map[string]interface{}{"attachments": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Attachments == nil {
+		t.Fatal("expected a non-nil Attachments iterator")
+	}
+	att, err := doc.Attachments.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if att.Filename != "foo.txt" {
+		t.Errorf("unexpected filename: %s", att.Filename)
+	}
+}
+
+func TestDBGetWithoutAttachmentsOption(t *testing.T) {
+	db := &DB{client: &mock.DB{
+		GetFunc: func(_ context.Context, docID string, _ map[string]interface{}) (*driver.Document, error) {
+			return &driver.Document{Rev: "1-xxx", Body: ioutil.NopCloser(bytes.NewReader([]byte(`{}`)))}, nil
+		},
+	}}
+	doc, err := db.Get(context.Background(), "doc1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Attachments != nil {
+		t.Error("expected a nil Attachments iterator")
+	}
+}
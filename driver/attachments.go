@@ -0,0 +1,39 @@
+package driver
+
+import "io"
+
+// Attachment represents a file attachment, as returned by a driver. It
+// mirrors the shape of a CouchDB attachment stub or, when Follows is set,
+// the as-yet-unread body of a multipart/related part.
+type Attachment struct {
+	// Filename is the name of the attachment.
+	Filename string
+	// ContentType is the MIME type of the attachment.
+	ContentType string
+	// Stub is true if the attachment content is not included, and must be
+	// fetched separately.
+	Stub bool
+	// Follows is true if the attachment content follows as a subsequent
+	// part of a multipart/related response or request.
+	Follows bool
+	// Content holds the attachment body. For a Stub, it should be an
+	// empty reader. For Follows, it is only valid once the corresponding
+	// multipart part has been read.
+	Content io.ReadCloser
+	// Size is the content length of the attachment, in bytes.
+	Size int64
+	// RevPos is the revision number when this attachment was added.
+	RevPos int64
+}
+
+// Attachments is an iterator over a sequence of attachments. It is returned
+// by a MultipartAttacher's GetMultipart method, and is also used to stream
+// attachments to PutMultipart in the order they should appear on the wire.
+type Attachments interface {
+	// Next populates att with the next attachment in the sequence. It
+	// returns io.EOF when the iteration is complete.
+	Next(att *Attachment) error
+	// Close closes the iterator, releasing any associated resources, such
+	// as the underlying multipart reader's HTTP response body.
+	Close() error
+}
@@ -0,0 +1,78 @@
+package driver
+
+import "context"
+
+// BulkGetReference identifies a single document revision to be fetched by
+// a BulkGetter.
+type BulkGetReference struct {
+	// ID is the document ID.
+	ID string
+	// Rev is the specific revision to fetch. If empty, the winning
+	// revision is returned.
+	Rev string
+}
+
+// BulkResult is a single row of a bulk operation, as returned by a
+// BulkResults iterator.
+type BulkResult struct {
+	// ID is the document ID this row pertains to.
+	ID string
+	// Rev is the resulting (or fetched) revision, when Ok is true.
+	Rev string
+	// Doc is the raw document body, populated by BulkGetter for
+	// successfully fetched rows.
+	Doc []byte
+	// Ok is true if this row completed successfully.
+	Ok bool
+	// Error, when non-nil, explains why this row failed. It is populated
+	// instead of Doc/Rev when Ok is false.
+	Error error
+}
+
+// BulkResults is an iterator over the rows of a bulk operation. It has the
+// same Next/Close shape as Attachments, so callers can stream results
+// (e.g. from a 100k-document _bulk_docs response) without buffering the
+// whole result set in memory.
+//
+// This package only defines the contract. internal/refdriver has a working
+// implementation — decoding the server's response incrementally with
+// json.Decoder.Token/Decode, and closing the underlying HTTP response body
+// as soon as ctx is canceled — used by this module's own tests in place of
+// a real CouchDB driver.
+type BulkResults interface {
+	// Next populates result with the next row. It returns io.EOF when the
+	// iteration is complete.
+	Next(result *BulkResult) error
+	// Close closes the iterator, releasing any associated resources, such
+	// as the underlying HTTP response body.
+	Close() error
+}
+
+// BulkGetter is an optional interface that may be implemented by a DB that
+// supports CouchDB's _bulk_get endpoint, fetching many document revisions
+// in a single round trip.
+type BulkGetter interface {
+	// BulkGet fetches the requested document revisions, streaming the
+	// results back via the returned BulkResults.
+	BulkGet(ctx context.Context, docs []BulkGetReference, options map[string]interface{}) (BulkResults, error)
+}
+
+// BulkDocer is an optional interface that may be implemented by a DB that
+// supports CouchDB's _bulk_docs endpoint. It is all-or-nothing: the full
+// result set is buffered before it is returned.
+type BulkDocer interface {
+	// BulkDocs creates or updates the given documents, returning one
+	// BulkResult per input document, in order.
+	BulkDocs(ctx context.Context, docs []interface{}, options map[string]interface{}) ([]BulkResult, error)
+}
+
+// BulkDocsStreamer is an optional interface that may be implemented by a DB
+// that can stream the results of a _bulk_docs call, rather than buffering
+// the full result set, so that inserting a very large number of documents
+// doesn't require holding them all in memory at once.
+type BulkDocsStreamer interface {
+	// BulkDocsStream creates or updates the given documents, returning a
+	// BulkResults iterator that decodes the server's response one row at
+	// a time.
+	BulkDocsStream(ctx context.Context, docs []interface{}, options map[string]interface{}) (BulkResults, error)
+}
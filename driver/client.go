@@ -0,0 +1,39 @@
+package driver
+
+import (
+	"context"
+	"io"
+)
+
+// Driver is implemented by database drivers that have been registered with
+// Kivik.
+type Driver interface {
+	// NewClient returns a client connection to a database server.
+	NewClient(name string) (Client, error)
+}
+
+// Client is a connection to a database server, as returned by a Driver's
+// NewClient method.
+type Client interface {
+	// DB returns a handle to the requested database.
+	DB(ctx context.Context, dbName string, options map[string]interface{}) (DB, error)
+}
+
+// Document is a single document as returned by a DB's Get method.
+type Document struct {
+	// ContentLength is the length of Body, if known.
+	ContentLength int64
+	// Rev is the revision of the returned document.
+	Rev string
+	// Body is the raw JSON of the document itself.
+	Body io.ReadCloser
+}
+
+// DB is a handle to a specific database.
+type DB interface {
+	// Get fetches the requested document.
+	Get(ctx context.Context, docID string, options map[string]interface{}) (*Document, error)
+	// Put writes the document, which may be a JSON-marshalable object or
+	// raw JSON, under docID.
+	Put(ctx context.Context, docID string, doc interface{}, options map[string]interface{}) (rev string, err error)
+}
@@ -0,0 +1,68 @@
+package driver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Error is a driver-level error, annotated with the HTTP status code of the
+// response that produced it and, where available, CouchDB's
+// machine-readable "reason" string. Package kivik unwraps these into its
+// own *Error so callers can match them with errors.Is against one of its
+// exported sentinels.
+type Error struct {
+	// HTTPStatus is the HTTP status code of the response that produced
+	// this error.
+	HTTPStatus int
+	// Reason is CouchDB's machine-readable error reason, when known.
+	Reason string
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return e.Reason
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode returns the HTTP status code associated with the error.
+func (e *Error) StatusCode() int {
+	return e.HTTPStatus
+}
+
+// couchError is the shape of CouchDB's JSON error body, e.g.
+// {"error":"not_found","reason":"missing"}.
+type couchError struct {
+	Error  string `json:"error"`
+	Reason string `json:"reason"`
+}
+
+// FromHTTPResponse builds an error from an HTTP response with a non-2xx
+// status code, parsing CouchDB's {"error":"...","reason":"..."} body when
+// present. It consumes and closes resp.Body, and returns nil if resp's
+// status code indicates success.
+func FromHTTPResponse(resp *http.Response) error {
+	defer resp.Body.Close() // nolint: errcheck
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	var ce couchError
+	_ = json.NewDecoder(resp.Body).Decode(&ce)
+	msg := ce.Error
+	if msg == "" {
+		msg = http.StatusText(resp.StatusCode)
+	}
+	return &Error{
+		HTTPStatus: resp.StatusCode,
+		Reason:     ce.Reason,
+		Err:        fmt.Errorf("%s", msg),
+	}
+}
@@ -0,0 +1,66 @@
+package driver
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestFromHTTPResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		resp       *http.Response
+		wantNil    bool
+		wantStatus int
+		wantReason string
+	}{
+		{
+			name:    "success",
+			resp:    &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(""))},
+			wantNil: true,
+		},
+		{
+			name:       "not found with body",
+			resp:       &http.Response{StatusCode: http.StatusNotFound, Body: ioutil.NopCloser(strings.NewReader(`{"error":"not_found","reason":"missing"}`))},
+			wantStatus: http.StatusNotFound,
+			wantReason: "missing",
+		},
+		{
+			name:       "error with no body",
+			resp:       &http.Response{StatusCode: http.StatusInternalServerError, Body: ioutil.NopCloser(strings.NewReader(""))},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := FromHTTPResponse(test.resp)
+			if test.wantNil {
+				if err != nil {
+					t.Fatalf("expected nil error, got %v", err)
+				}
+				return
+			}
+			de, ok := err.(*Error)
+			if !ok {
+				t.Fatalf("expected *Error, got %T", err)
+			}
+			if de.StatusCode() != test.wantStatus {
+				t.Errorf("unexpected status: %d", de.StatusCode())
+			}
+			if de.Reason != test.wantReason {
+				t.Errorf("unexpected reason: %s", de.Reason)
+			}
+			if de.Unwrap() == nil {
+				t.Error("expected a non-nil wrapped error")
+			}
+		})
+	}
+}
+
+func TestErrorNoErr(t *testing.T) {
+	err := &Error{HTTPStatus: http.StatusNotFound, Reason: "missing"}
+	if got := err.Error(); got != "missing" {
+		t.Errorf("unexpected message: %s", got)
+	}
+}
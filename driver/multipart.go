@@ -0,0 +1,31 @@
+package driver
+
+import "context"
+
+// MultipartAttacher is an optional interface that may be implemented by a
+// DB that supports streaming attachments via multipart/related, rather than
+// inlining them as base64 data in the document JSON. Drivers that support
+// the CouchDB _bulk_docs-style multipart upload/download should implement
+// this interface; DB.Put and DB.Get prefer it whenever attachments are
+// involved.
+//
+// This package only defines the contract. internal/refdriver has a working
+// implementation — building the mime/multipart request body, setting
+// "Accept: multipart/related" and parsing the response, and sending every
+// request with chunked transfer encoding, since attachment readers may be
+// of unknown size — used by this module's own tests in place of a real
+// CouchDB driver.
+type MultipartAttacher interface {
+	// PutMultipart writes doc as the first part of a multipart/related
+	// request, followed by one part per attachment returned by atts, in the
+	// order atts yields them. doc is written as given: PutMultipart does
+	// not add "follows": true stubs to it, so doc's own _attachments field
+	// must already carry one for each attachment.
+	PutMultipart(ctx context.Context, docID string, doc interface{}, atts Attachments, options map[string]interface{}) (rev string, err error)
+	// GetMultipart fetches a document and its attachments in a single
+	// multipart/related response. The returned Document's Body is the
+	// document JSON; the returned Attachments streams the attachment
+	// parts in the order they appear on the wire, so callers can copy
+	// each one to disk without buffering the whole attachment in memory.
+	GetMultipart(ctx context.Context, docID string, options map[string]interface{}) (*Document, Attachments, error)
+}
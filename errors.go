@@ -0,0 +1,113 @@
+package kivik
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/IG-Soft/kivik/v3/driver"
+)
+
+// Error is an error returned by Kivik, optionally annotated with the HTTP
+// status code of the response that produced it and CouchDB's
+// machine-readable "reason" string. Use errors.Is against one of the
+// Err* sentinels below to test for a specific condition, rather than
+// comparing HTTPStatus directly.
+type Error struct {
+	// HTTPStatus is the HTTP status code associated with this error, or 0
+	// if none is applicable.
+	HTTPStatus int
+	// Reason is CouchDB's machine-readable error reason, when known.
+	Reason string
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return e.Reason
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode returns the HTTP status code associated with the error.
+func (e *Error) StatusCode() int {
+	return e.HTTPStatus
+}
+
+// Is reports whether target is an equivalent *Error. A sentinel matches
+// any error with the same HTTPStatus, further narrowed by Reason when the
+// sentinel specifies one.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	if t.HTTPStatus != 0 && t.HTTPStatus != e.HTTPStatus {
+		return false
+	}
+	if t.Reason != "" && t.Reason != e.Reason {
+		return false
+	}
+	return true
+}
+
+// Sentinel errors, for use with errors.Is, e.g.
+// errors.Is(err, kivik.ErrNotFound).
+var (
+	ErrNotFound           error = &Error{HTTPStatus: http.StatusNotFound}
+	ErrConflict           error = &Error{HTTPStatus: http.StatusConflict}
+	ErrUnauthorized       error = &Error{HTTPStatus: http.StatusUnauthorized}
+	ErrForbidden          error = &Error{HTTPStatus: http.StatusForbidden}
+	ErrNotModified        error = &Error{HTTPStatus: http.StatusNotModified}
+	ErrPreconditionFailed error = &Error{HTTPStatus: http.StatusPreconditionFailed}
+	ErrBadRequest         error = &Error{HTTPStatus: http.StatusBadRequest}
+)
+
+// MultiError aggregates the per-row errors of a bulk operation into a
+// single error. errors.Is(multiErr, target) reports true if any contained
+// error matches target, so callers can write
+// errors.Is(err, kivik.ErrConflict) without caring whether err came from a
+// single request or a bulk one.
+type MultiError struct {
+	Errors []error
+}
+
+func (e *MultiError) Error() string {
+	switch len(e.Errors) {
+	case 0:
+		return "kivik: no errors"
+	case 1:
+		return e.Errors[0].Error()
+	default:
+		return fmt.Sprintf("%d errors, including: %s", len(e.Errors), e.Errors[0])
+	}
+}
+
+// Is reports whether any of e.Errors matches target.
+func (e *MultiError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapError converts a *driver.Error, as returned by a driver, into an
+// equivalent *Error, so that it can be matched against the Err* sentinels
+// with errors.Is. An error that wraps a *driver.Error is converted the same
+// way, and the wrapping around it is dropped. Any other error is returned
+// unchanged.
+func wrapError(err error) error {
+	var de *driver.Error
+	if errors.As(err, &de) {
+		return &Error{HTTPStatus: de.HTTPStatus, Reason: de.Reason, Err: de.Err}
+	}
+	return err
+}
@@ -0,0 +1,73 @@
+package kivik
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/IG-Soft/kivik/v3/driver"
+)
+
+func TestErrorIsSentinel(t *testing.T) {
+	err := &Error{HTTPStatus: http.StatusConflict, Reason: "document update conflict", Err: errors.New("conflict")}
+	if !errors.Is(err, ErrConflict) {
+		t.Error("expected err to match ErrConflict")
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Error("expected err not to match ErrNotFound")
+	}
+}
+
+func TestErrorIsSentinelByReason(t *testing.T) {
+	sentinel := &Error{HTTPStatus: http.StatusForbidden, Reason: "read_only"}
+	matching := &Error{HTTPStatus: http.StatusForbidden, Reason: "read_only", Err: errors.New("x")}
+	other := &Error{HTTPStatus: http.StatusForbidden, Reason: "not_allowed", Err: errors.New("x")}
+	if !errors.Is(matching, sentinel) {
+		t.Error("expected matching reason to match")
+	}
+	if errors.Is(other, sentinel) {
+		t.Error("expected differing reason not to match")
+	}
+}
+
+func TestErrorUnwrap(t *testing.T) {
+	inner := errors.New("boom")
+	err := &Error{HTTPStatus: http.StatusBadGateway, Err: inner}
+	if !errors.Is(err, inner) {
+		t.Error("expected errors.Is to find the wrapped error")
+	}
+}
+
+func TestWrapErrorConvertsDriverError(t *testing.T) {
+	de := &driver.Error{HTTPStatus: http.StatusNotFound, Reason: "missing", Err: errors.New("not found")}
+	err := wrapError(de)
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("expected the wrapped error to match ErrNotFound")
+	}
+	if _, ok := err.(*Error); !ok {
+		t.Errorf("expected *Error, got %T", err)
+	}
+}
+
+func TestWrapErrorPassesThroughOtherErrors(t *testing.T) {
+	orig := errors.New("something else")
+	if wrapError(orig) != orig {
+		t.Error("expected a non-driver error to be returned unchanged")
+	}
+	if wrapError(nil) != nil {
+		t.Error("expected a nil error to be returned unchanged")
+	}
+}
+
+func TestMultiErrorIs(t *testing.T) {
+	me := &MultiError{Errors: []error{
+		&Error{HTTPStatus: http.StatusNotFound},
+		&Error{HTTPStatus: http.StatusConflict},
+	}}
+	if !errors.Is(me, ErrConflict) {
+		t.Error("expected MultiError to match ErrConflict")
+	}
+	if errors.Is(me, ErrForbidden) {
+		t.Error("expected MultiError not to match ErrForbidden")
+	}
+}
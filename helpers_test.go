@@ -0,0 +1,11 @@
+package kivik
+
+import "errors"
+
+// errorReader is an io.Reader that always returns an error, for exercising
+// error-handling paths that read from an Attachment's Content.
+type errorReader struct{}
+
+func (errorReader) Read(_ []byte) (int, error) {
+	return 0, errors.New("errorReader")
+}
@@ -0,0 +1,26 @@
+package mock
+
+import (
+	"github.com/IG-Soft/kivik/v3/driver"
+)
+
+// Attachments mocks a driver.Attachments iterator.
+type Attachments struct {
+	NextFunc  func(*driver.Attachment) error
+	CloseFunc func() error
+}
+
+var _ driver.Attachments = &Attachments{}
+
+// Next calls a.NextFunc.
+func (a *Attachments) Next(att *driver.Attachment) error {
+	return a.NextFunc(att)
+}
+
+// Close calls a.CloseFunc, or returns nil if it is unset.
+func (a *Attachments) Close() error {
+	if a.CloseFunc == nil {
+		return nil
+	}
+	return a.CloseFunc()
+}
@@ -0,0 +1,68 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/IG-Soft/kivik/v3/driver"
+)
+
+// BulkResults mocks a driver.BulkResults iterator.
+type BulkResults struct {
+	NextFunc  func(*driver.BulkResult) error
+	CloseFunc func() error
+}
+
+var _ driver.BulkResults = &BulkResults{}
+
+// Next calls r.NextFunc.
+func (r *BulkResults) Next(result *driver.BulkResult) error {
+	return r.NextFunc(result)
+}
+
+// Close calls r.CloseFunc, or returns nil if it is unset.
+func (r *BulkResults) Close() error {
+	if r.CloseFunc == nil {
+		return nil
+	}
+	return r.CloseFunc()
+}
+
+// BulkGetDB mocks a driver.DB that also implements driver.BulkGetter.
+type BulkGetDB struct {
+	DB
+	BulkGetFunc func(ctx context.Context, docs []driver.BulkGetReference, options map[string]interface{}) (driver.BulkResults, error)
+}
+
+var _ driver.BulkGetter = &BulkGetDB{}
+
+// BulkGet calls db.BulkGetFunc.
+func (db *BulkGetDB) BulkGet(ctx context.Context, docs []driver.BulkGetReference, options map[string]interface{}) (driver.BulkResults, error) {
+	return db.BulkGetFunc(ctx, docs, options)
+}
+
+// BulkDocerDB mocks a driver.DB that also implements driver.BulkDocer.
+type BulkDocerDB struct {
+	DB
+	BulkDocsFunc func(ctx context.Context, docs []interface{}, options map[string]interface{}) ([]driver.BulkResult, error)
+}
+
+var _ driver.BulkDocer = &BulkDocerDB{}
+
+// BulkDocs calls db.BulkDocsFunc.
+func (db *BulkDocerDB) BulkDocs(ctx context.Context, docs []interface{}, options map[string]interface{}) ([]driver.BulkResult, error) {
+	return db.BulkDocsFunc(ctx, docs, options)
+}
+
+// BulkDocsStreamDB mocks a driver.DB that also implements
+// driver.BulkDocsStreamer.
+type BulkDocsStreamDB struct {
+	DB
+	BulkDocsStreamFunc func(ctx context.Context, docs []interface{}, options map[string]interface{}) (driver.BulkResults, error)
+}
+
+var _ driver.BulkDocsStreamer = &BulkDocsStreamDB{}
+
+// BulkDocsStream calls db.BulkDocsStreamFunc.
+func (db *BulkDocsStreamDB) BulkDocsStream(ctx context.Context, docs []interface{}, options map[string]interface{}) (driver.BulkResults, error) {
+	return db.BulkDocsStreamFunc(ctx, docs, options)
+}
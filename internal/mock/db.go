@@ -0,0 +1,44 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/IG-Soft/kivik/v3/driver"
+)
+
+// DB mocks a driver.DB.
+type DB struct {
+	GetFunc func(ctx context.Context, docID string, options map[string]interface{}) (*driver.Document, error)
+	PutFunc func(ctx context.Context, docID string, doc interface{}, options map[string]interface{}) (rev string, err error)
+}
+
+var _ driver.DB = &DB{}
+
+// Get calls db.GetFunc.
+func (db *DB) Get(ctx context.Context, docID string, options map[string]interface{}) (*driver.Document, error) {
+	return db.GetFunc(ctx, docID, options)
+}
+
+// Put calls db.PutFunc.
+func (db *DB) Put(ctx context.Context, docID string, doc interface{}, options map[string]interface{}) (string, error) {
+	return db.PutFunc(ctx, docID, doc, options)
+}
+
+// MultipartDB mocks a driver.DB that also implements driver.MultipartAttacher.
+type MultipartDB struct {
+	DB
+	PutMultipartFunc func(ctx context.Context, docID string, doc interface{}, atts driver.Attachments, options map[string]interface{}) (rev string, err error)
+	GetMultipartFunc func(ctx context.Context, docID string, options map[string]interface{}) (*driver.Document, driver.Attachments, error)
+}
+
+var _ driver.MultipartAttacher = &MultipartDB{}
+
+// PutMultipart calls db.PutMultipartFunc.
+func (db *MultipartDB) PutMultipart(ctx context.Context, docID string, doc interface{}, atts driver.Attachments, options map[string]interface{}) (string, error) {
+	return db.PutMultipartFunc(ctx, docID, doc, atts, options)
+}
+
+// GetMultipart calls db.GetMultipartFunc.
+func (db *MultipartDB) GetMultipart(ctx context.Context, docID string, options map[string]interface{}) (*driver.Document, driver.Attachments, error) {
+	return db.GetMultipartFunc(ctx, docID, options)
+}
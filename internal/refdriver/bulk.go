@@ -0,0 +1,120 @@
+package refdriver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/IG-Soft/kivik/v3/driver"
+)
+
+var _ driver.BulkDocsStreamer = &DB{}
+
+// BulkDocsStream posts docs to _bulk_docs and decodes the server's JSON
+// array response one element at a time with json.Decoder, rather than
+// buffering the whole array, so a very large insert doesn't require
+// holding every result in memory. If ctx is canceled before the stream is
+// fully drained, the underlying HTTP response body is closed immediately,
+// so the connection isn't held open waiting for a reader that will never
+// come.
+func (db *DB) BulkDocsStream(ctx context.Context, docs []interface{}, _ map[string]interface{}) (driver.BulkResults, error) {
+	reqBody, err := json.Marshal(map[string]interface{}{"docs": docs})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, db.dbURL+"/_bulk_docs", bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := db.hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		return nil, driver.FromHTTPResponse(resp)
+	}
+	dec := json.NewDecoder(resp.Body)
+	if _, err := dec.Token(); err != nil { // consume the opening '['
+		resp.Body.Close() // nolint: errcheck
+		return nil, err
+	}
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			resp.Body.Close() // nolint: errcheck
+		case <-done:
+		}
+	}()
+	return &bulkDocsStream{dec: dec, body: resp.Body, done: done}, nil
+}
+
+type bulkDocsStream struct {
+	dec    *json.Decoder
+	body   io.ReadCloser
+	done   chan struct{}
+	closed bool
+}
+
+type bulkDocsRow struct {
+	ID     string `json:"id"`
+	Rev    string `json:"rev"`
+	Error  string `json:"error"`
+	Reason string `json:"reason"`
+}
+
+func (s *bulkDocsStream) Next(result *driver.BulkResult) error {
+	if !s.dec.More() {
+		return io.EOF
+	}
+	var row bulkDocsRow
+	if err := s.dec.Decode(&row); err != nil {
+		return err
+	}
+	if row.Error != "" {
+		*result = driver.BulkResult{
+			ID: row.ID,
+			Error: &driver.Error{
+				HTTPStatus: statusForReason(row.Error),
+				Reason:     row.Reason,
+				Err:        fmt.Errorf("%s: %s", row.Error, row.Reason),
+			},
+		}
+		return nil
+	}
+	*result = driver.BulkResult{ID: row.ID, Rev: row.Rev, Ok: true}
+	return nil
+}
+
+func (s *bulkDocsStream) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	close(s.done)
+	return s.body.Close()
+}
+
+// statusForReason maps a CouchDB bulk-row error name to the HTTP status it
+// corresponds to when returned from a single-document request, so callers
+// can match it with errors.Is against kivik's sentinel errors.
+func statusForReason(name string) int {
+	switch name {
+	case "conflict":
+		return http.StatusConflict
+	case "forbidden":
+		return http.StatusForbidden
+	case "unauthorized":
+		return http.StatusUnauthorized
+	case "not_found":
+		return http.StatusNotFound
+	case "bad_request":
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,150 @@
+package refdriver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strconv"
+
+	"github.com/IG-Soft/kivik/v3/driver"
+)
+
+var _ driver.MultipartAttacher = &DB{}
+
+// PutMultipart writes doc as the first part of a multipart/related
+// request, with any "follows": true stubs in doc's own JSON standing in
+// for the attachment bodies streamed in the parts that follow, in the
+// order atts yields them. The request is streamed through a pipe with no
+// Content-Length, since the combined size of arbitrary attachment readers
+// isn't known up front; the underlying transport falls back to chunked
+// transfer encoding for this, as net/http does for any non-seekable body
+// with ContentLength < 0.
+func (db *DB) PutMultipart(ctx context.Context, docID string, doc interface{}, atts driver.Attachments, _ map[string]interface{}) (string, error) {
+	pr, pw := io.Pipe()
+	mpw := multipart.NewWriter(pw)
+	go writeMultipartDoc(pw, mpw, doc, atts)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, db.docURL(docID), pr)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "multipart/related; boundary="+mpw.Boundary())
+	req.ContentLength = -1
+	resp, err := db.hc.Do(req)
+	if err != nil {
+		return "", err
+	}
+	return decodeRev(resp)
+}
+
+func writeMultipartDoc(pw *io.PipeWriter, mpw *multipart.Writer, doc interface{}, atts driver.Attachments) {
+	err := func() error {
+		docJSON, err := json.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		docPart, err := mpw.CreatePart(textproto.MIMEHeader{"Content-Type": {"application/json"}})
+		if err != nil {
+			return err
+		}
+		if _, err := docPart.Write(docJSON); err != nil {
+			return err
+		}
+		for {
+			var att driver.Attachment
+			nextErr := atts.Next(&att)
+			if nextErr == io.EOF {
+				return mpw.Close()
+			}
+			if nextErr != nil {
+				return nextErr
+			}
+			header := textproto.MIMEHeader{"Content-Type": {att.ContentType}}
+			if att.Size > 0 {
+				header.Set("Content-Length", strconv.FormatInt(att.Size, 10))
+			}
+			part, err := mpw.CreatePart(header)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(part, att.Content); err != nil {
+				return err
+			}
+		}
+	}()
+	if err != nil {
+		pw.CloseWithError(err)
+		return
+	}
+	pw.Close() // nolint: errcheck
+}
+
+// GetMultipart fetches a document and its attachments in a single
+// multipart/related response, requested via "Accept: multipart/related".
+// The returned Attachments reads parts lazily off the response body as
+// the caller calls Next, so a large attachment is never buffered whole;
+// closing the iterator (or draining it to io.EOF) releases the
+// connection.
+func (db *DB) GetMultipart(ctx context.Context, docID string, _ map[string]interface{}) (*driver.Document, driver.Attachments, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, db.docURL(docID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "multipart/related")
+	resp, err := db.hc.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode >= 400 {
+		return nil, nil, driver.FromHTTPResponse(resp)
+	}
+	_, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil {
+		resp.Body.Close() // nolint: errcheck
+		return nil, nil, err
+	}
+	mr := multipart.NewReader(resp.Body, params["boundary"])
+	docPart, err := mr.NextPart()
+	if err != nil {
+		resp.Body.Close() // nolint: errcheck
+		return nil, nil, err
+	}
+	docJSON, err := ioutil.ReadAll(docPart)
+	if err != nil {
+		resp.Body.Close() // nolint: errcheck
+		return nil, nil, err
+	}
+	doc := &driver.Document{
+		Body:          ioutil.NopCloser(bytes.NewReader(docJSON)),
+		ContentLength: int64(len(docJSON)),
+	}
+	return doc, &multipartAttachments{mr: mr, body: resp.Body}, nil
+}
+
+type multipartAttachments struct {
+	mr   *multipart.Reader
+	body io.ReadCloser
+}
+
+func (a *multipartAttachments) Next(att *driver.Attachment) error {
+	part, err := a.mr.NextPart()
+	if err != nil {
+		return err
+	}
+	*att = driver.Attachment{
+		Filename:    part.FileName(),
+		ContentType: part.Header.Get("Content-Type"),
+		Content:     ioutil.NopCloser(part),
+	}
+	return nil
+}
+
+func (a *multipartAttachments) Close() error {
+	return a.body.Close()
+}
@@ -0,0 +1,132 @@
+// Package refdriver is a minimal, in-repo reference implementation of the
+// driver interfaces. Its only purpose is to exercise the multipart/related
+// and streaming _bulk_docs wire formats end-to-end, against a real HTTP
+// transport (such as an httptest.Server), in this module's own tests. It
+// is not a production CouchDB driver: there's no auth, no retries, and no
+// query options beyond what those tests need.
+package refdriver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/IG-Soft/kivik/v3/driver"
+)
+
+// Driver is a reference driver.Driver, pointed at an HTTP server such as
+// an httptest.Server.
+type Driver struct {
+	// HTTPClient is the client used to make requests. Defaults to
+	// http.DefaultClient if nil.
+	HTTPClient *http.Client
+}
+
+var _ driver.Driver = &Driver{}
+
+// NewClient returns a Client, treating name as the server's base URL.
+func (d *Driver) NewClient(name string) (driver.Client, error) {
+	hc := d.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	return &client{baseURL: name, hc: hc}, nil
+}
+
+type client struct {
+	baseURL string
+	hc      *http.Client
+}
+
+var _ driver.Client = &client{}
+
+func (c *client) DB(_ context.Context, dbName string, _ map[string]interface{}) (driver.DB, error) {
+	return &DB{dbURL: c.baseURL + "/" + dbName, hc: c.hc}, nil
+}
+
+// DB is a handle to a single database on the reference server.
+type DB struct {
+	dbURL string
+	hc    *http.Client
+}
+
+var _ driver.DB = &DB{}
+
+func (db *DB) docURL(docID string) string {
+	return db.dbURL + "/" + docID
+}
+
+// readAndRewind drains resp.Body into memory and replaces it with a fresh
+// reader over the same bytes, so the body can be inspected for errors via
+// driver.FromHTTPResponse (which always consumes and closes it) and then
+// decoded again by the caller.
+func readAndRewind(resp *http.Response) ([]byte, error) {
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close() // nolint: errcheck
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+func decodeRev(resp *http.Response) (string, error) {
+	body, err := readAndRewind(resp)
+	if err != nil {
+		return "", err
+	}
+	if err := driver.FromHTTPResponse(resp); err != nil {
+		return "", err
+	}
+	var result struct {
+		Rev string `json:"rev"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", err
+	}
+	return result.Rev, nil
+}
+
+// Get fetches a document as plain JSON, with no attachment handling.
+func (db *DB) Get(ctx context.Context, docID string, _ map[string]interface{}) (*driver.Document, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, db.docURL(docID), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := db.hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := readAndRewind(resp)
+	if err != nil {
+		return nil, err
+	}
+	if err := driver.FromHTTPResponse(resp); err != nil {
+		return nil, err
+	}
+	return &driver.Document{
+		Rev:           resp.Header.Get("ETag"),
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}, nil
+}
+
+// Put writes doc as plain, fully-inlined JSON.
+func (db *DB) Put(ctx context.Context, docID string, doc interface{}, _ map[string]interface{}) (string, error) {
+	docJSON, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, db.docURL(docID), bytes.NewReader(docJSON))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := db.hc.Do(req)
+	if err != nil {
+		return "", err
+	}
+	return decodeRev(resp)
+}
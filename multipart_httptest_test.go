@@ -0,0 +1,155 @@
+package kivik
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+
+	"github.com/IG-Soft/kivik/v3/internal/refdriver"
+)
+
+// newRefDB wires a kivik.DB straight to a refdriver.DB pointed at server,
+// the same way Client.DB does, without going through the global driver
+// registry.
+func newRefDB(t *testing.T, server *httptest.Server) *DB {
+	t.Helper()
+	driverClient, err := (&refdriver.Driver{HTTPClient: server.Client()}).NewClient(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	driverDB, err := driverClient.DB(context.Background(), "testdb", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &DB{client: driverDB}
+}
+
+// TestDBPutMultipartWire drives DB.Put against a real HTTP server to
+// confirm the "follows" marshal path round-trips correctly on the wire:
+// the doc JSON part carries "follows": true stubs, and the attachment
+// bodies that follow land in filename order.
+func TestDBPutMultipartWire(t *testing.T) {
+	var gotContentType string
+	var gotParts []struct {
+		contentType string
+		body        string
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotContentType = r.Header.Get("Content-Type")
+		_, params, err := mime.ParseMediaType(gotContentType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mr := multipart.NewReader(r.Body, params["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if err != nil {
+				break
+			}
+			body, err := ioutil.ReadAll(part)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotParts = append(gotParts, struct {
+				contentType string
+				body        string
+			}{part.Header.Get("Content-Type"), string(body)})
+		}
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "doc1", "rev": "1-xxx"})
+	}))
+	defer server.Close()
+
+	db := newRefDB(t, server)
+	atts := Attachments{
+		"b.txt": {Content: ioutil.NopCloser(bytes.NewReader([]byte("bbb"))), ContentType: "text/plain"},
+		"a.txt": {Content: ioutil.NopCloser(bytes.NewReader([]byte("aaa"))), ContentType: "text/plain"},
+	}
+	doc := map[string]interface{}{
+		"_attachments": Attachments{
+			"a.txt": {ContentType: "text/plain", Follows: true},
+			"b.txt": {ContentType: "text/plain", Follows: true},
+		},
+	}
+	rev, err := db.Put(context.Background(), "doc1", doc, atts, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rev != "1-xxx" {
+		t.Errorf("unexpected rev: %s", rev)
+	}
+	if len(gotParts) != 3 {
+		t.Fatalf("expected 3 MIME parts (doc + 2 attachments), got %d", len(gotParts))
+	}
+	if gotParts[0].contentType != "application/json" {
+		t.Errorf("unexpected first part content type: %s", gotParts[0].contentType)
+	}
+	var gotDoc map[string]interface{}
+	if err := json.Unmarshal([]byte(gotParts[0].body), &gotDoc); err != nil {
+		t.Fatal(err)
+	}
+	atts0 := gotDoc["_attachments"].(map[string]interface{})["a.txt"].(map[string]interface{})
+	if atts0["follows"] != true {
+		t.Error(`expected "a.txt" stub to carry "follows": true`)
+	}
+	if gotParts[1].body != "aaa" || gotParts[2].body != "bbb" {
+		t.Errorf("attachment parts out of order: %q, %q", gotParts[1].body, gotParts[2].body)
+	}
+}
+
+// TestDBGetMultipartWire drives DB.Get against a real multipart/related
+// HTTP response to confirm attachments stream back without being
+// buffered into the document JSON.
+func TestDBGetMultipartWire(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "multipart/related" {
+			t.Errorf(`expected "Accept: multipart/related", got %q`, r.Header.Get("Accept"))
+		}
+		mpw := multipart.NewWriter(w)
+		w.Header().Set("Content-Type", "multipart/related; boundary="+mpw.Boundary())
+		w.WriteHeader(http.StatusOK)
+		docPart, _ := mpw.CreatePart(textproto.MIMEHeader{"Content-Type": {"application/json"}})
+		_, _ = docPart.Write([]byte(`{"_id":"doc1","_attachments":{"foo.txt":{"content_type":"text/plain","follows":true}}}`))
+		attPart, _ := mpw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":        {"text/plain"},
+			"Content-Disposition": {`attachment; filename="foo.txt"`},
+		})
+		_, _ = attPart.Write([]byte("attachment content"))
+		_ = mpw.Close()
+	}))
+	defer server.Close()
+
+	db := newRefDB(t, server)
+	doc, err := db.Get(context.Background(), "doc1", map[string]interface{}{"attachments": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Attachments == nil {
+		t.Fatal("expected a non-nil Attachments iterator")
+	}
+	att, err := doc.Attachments.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if att.Filename != "foo.txt" {
+		t.Errorf("unexpected filename: %s", att.Filename)
+	}
+	body, err := ioutil.ReadAll(att.Content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "attachment content" {
+		t.Errorf("unexpected attachment content: %s", body)
+	}
+	if _, err := doc.Attachments.Next(); err != io.EOF {
+		t.Error("expected io.EOF after the single attachment")
+	}
+}